@@ -44,7 +44,11 @@ func main() {
 		janitorInterval.String(),
 		idleTimeout.String(),
 	)
-	http.ListenAndServe(listenAddr, getHandler(tracker))
+
+	mux := http.NewServeMux()
+	mux.Handle("/", getHandler(tracker))
+	mux.Handle("/debug/sessions", getDebugSessionsHandler(tracker))
+	http.ListenAndServe(listenAddr, mux)
 }
 
 func getSessionInitFunc(sessions map[string]*session) slide.SessionInitFunc[*session] {
@@ -79,14 +83,38 @@ func getHandler(tracker slide.Tracker[*session]) http.Handler {
 		// new uuid for this specific http request
 		requestID := freshID()
 
-		sess := tracker.EventStart(dedupKey, requestID).Data()
+		session := tracker.EventStart(dedupKey, requestID)
+		if session == nil {
+			// dedupKey is still within its lock delay from a prior
+			// invalidation; this tracker isn't configured with
+			// WithLockDelay, but guard anyway since it's the only
+			// case EventStart returns nil.
+			http.Error(w, "session locked", http.StatusLocked)
+			return
+		}
 		defer tracker.EventEnd(requestID)
 
+		sess := session.Data()
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(fmt.Sprintf(`{"session_id": "%s", "src_ip": "%s", "reqs": %d}`, sess.id, sess.sourceIP, sess.reqs.Add(1))))
 	})
 }
 
+func getDebugSessionsHandler(tracker slide.Tracker[*session]) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats := tracker.Stats()
+		fmt.Fprintf(w, `{"active": %d, "retired": %d, "events_in_flight": %d}`+"\n",
+			stats.ActiveSessions, stats.RetiredSessions, stats.EventsInFlight)
+
+		tracker.Range(func(dedupKey string, sess *slide.Session[*session]) bool {
+			snap := sess.Snapshot()
+			fmt.Fprintf(w, `{"dedup_key": %q, "session_id": %q, "events": %d}`+"\n",
+				dedupKey, sess.Data().id, len(snap.EventIDs))
+			return true
+		})
+	})
+}
+
 func freshID() string {
 	buf := make([]byte, 10)
 	_, _ = rand.Read(buf)
@@ -1,5 +1,7 @@
 package slide
 
+import "time"
+
 // SessionInitFunc is a function that will be invoked to initialize
 // a new session whenever an event merits a new session.
 type SessionInitFunc[T any] func(sessionDedupKey string) T
@@ -9,9 +11,70 @@ type SessionInitFunc[T any] func(sessionDedupKey string) T
 // or because of the maximum session lifetime being exceeded.
 type OnEndFunc[T any] func(data T, lastUpdated *SessionMetadata)
 
+// OnDeleteFunc is a function that will be invoked whenever a session
+// with BehaviorDelete ends, in addition to its OnEndFunc, so that
+// callers can purge any resources they attached to the session.
+type OnDeleteFunc[T any] func(data T, lastUpdated *SessionMetadata)
+
+// LivenessCheckFunc is a function that will be invoked by the janitor
+// for every active session on every tick. Returning false invalidates
+// the session immediately, the same as if its inactivity timeout or
+// max session timeout had elapsed.
+type LivenessCheckFunc[T any] func(data T, metadata *SessionMetadata) bool
+
+// Behavior controls what happens to a session's attached resources
+// when it ends, whether by timeout or by explicit invalidation.
+type Behavior int
+
+const (
+	// BehaviorRelease runs the tracker's OnEndFunc and drops the
+	// session. This is the default.
+	BehaviorRelease Behavior = iota
+	// BehaviorDelete runs the tracker's OnEndFunc, drops the session,
+	// and additionally runs the tracker's OnDeleteFunc so callers can
+	// purge any resources they attached to the session.
+	BehaviorDelete
+)
+
+// EventSink receives notifications about tracker activity. Multiple
+// sinks may be registered via WithEventSink; every registered sink is
+// notified of every event.
+type EventSink[T any] interface {
+	OnEventStart(sessionDedupKey, eventID string)
+	OnEventEnd(sessionDedupKey, eventID string)
+	OnSessionCreated(sessionDedupKey string, metadata *SessionMetadata)
+	OnSessionRetired(sessionDedupKey string, metadata *SessionMetadata)
+	OnSessionEnded(sessionDedupKey string, data T, metadata *SessionMetadata)
+}
+
+// TrackerStats summarizes a Tracker's state at a point in time.
+type TrackerStats struct {
+	ActiveSessions    int
+	RetiredSessions   int
+	EventsInFlight    int
+	AverageSessionAge time.Duration
+}
+
 // Tracker represents a sliding window tracker.
 type Tracker[T any] interface {
 	Stop()
-	EventStart(sessionDedupKey string, eventID string) *Session[T]
+	// EventStart marks the start of a session's event, returning the
+	// session it belongs to. It returns nil if sessionDedupKey is
+	// still within its lock delay from a prior invalidation; callers
+	// using WithLockDelay must check for a nil return before treating
+	// the event as belonging to a session.
+	EventStart(sessionDedupKey string, eventID string, opts ...EventStartOption[T]) *Session[T]
 	EventEnd(eventID string) error
+	// Invalidate force-ends the active session for sessionDedupKey,
+	// without waiting for inactivity or the janitor.
+	Invalidate(sessionDedupKey string) error
+	// Get returns the active session for sessionDedupKey, if any.
+	Get(sessionDedupKey string) (*Session[T], bool)
+	// Range calls fn for every active session, stopping early if fn
+	// returns false.
+	Range(fn func(sessionDedupKey string, session *Session[T]) bool)
+	// Len returns the number of active sessions.
+	Len() int
+	// Stats returns a summary of the Tracker's current state.
+	Stats() TrackerStats
 }
@@ -28,3 +28,85 @@ func WithMaxSessionTimeout[T any](maxSessionTimeout time.Duration) Option[T] {
 func WithOnSessionEnd[T any](onSessionEnd OnEndFunc[T]) Option[T] {
 	return func(c *config[T]) { c.onSessionEnd = onSessionEnd }
 }
+
+// WithStore sets the Tracker's session Store, replacing the default
+// in-memory store. Use this to make sessions survive a process
+// restart, e.g. with a FileStore or RedisStore.
+func WithStore[T any](store Store[T]) Option[T] {
+	return func(c *config[T]) { c.store = store }
+}
+
+// WithSessionBehavior sets the Tracker's default session Behavior,
+// i.e. what happens to a session's attached resources when it ends.
+// It can be overridden per session via WithEventBehavior.
+func WithSessionBehavior[T any](behavior Behavior) Option[T] {
+	return func(c *config[T]) { c.sessionBehavior = behavior }
+}
+
+// WithOnDelete sets the Tracker's onDelete handler, i.e. a function
+// that will be ran, in addition to the onSessionEnd handler, on every
+// session whose Behavior is BehaviorDelete after it's done.
+func WithOnDelete[T any](onDelete OnDeleteFunc[T]) Option[T] {
+	return func(c *config[T]) { c.onDelete = onDelete }
+}
+
+// WithLivenessCheck sets the Tracker's liveness check, invoked for
+// every active session on every janitor tick. Returning false
+// invalidates the session immediately via the same path as a timeout.
+func WithLivenessCheck[T any](check LivenessCheckFunc[T]) Option[T] {
+	return func(c *config[T]) { c.livenessCheck = check }
+}
+
+// WithLockDelay sets the Tracker's lock delay: after a session is
+// invalidated (liveness check failure, or an explicit Invalidate), no
+// new session will be issued for the same dedup key until lockDelay
+// has elapsed. This matches Consul's LockDelay semantics and prevents
+// thundering-herd re-registration.
+func WithLockDelay[T any](lockDelay time.Duration) Option[T] {
+	return func(c *config[T]) { c.lockDelay = lockDelay }
+}
+
+// WithEventSink registers an EventSink with the Tracker. It may be
+// called more than once; every registered sink is notified of every
+// event.
+func WithEventSink[T any](sink EventSink[T]) Option[T] {
+	return func(c *config[T]) { c.eventSinks = append(c.eventSinks, sink) }
+}
+
+// WithJanitorJitter sets the Tracker's janitor jitter fraction f (in
+// [0,1]): instead of firing at a fixed cadence, each janitor sleep is
+// picked uniformly from [interval*(1-f), interval*(1+f)]. This keeps
+// many trackers in one process from all sweeping in lockstep.
+func WithJanitorJitter[T any](fraction float64) Option[T] {
+	return func(c *config[T]) { c.janitorJitter = fraction }
+}
+
+// WithAdaptiveJanitor puts the Tracker's janitor interval in adaptive
+// mode, bounded by min and max: after a sweep, the interval halves
+// (floored at min) if the sweep removed at least targetWork sessions,
+// and doubles (capped at max) if it removed none. This reduces wasted
+// work on mostly-idle trackers and reduces latency-to-cleanup on busy
+// ones.
+func WithAdaptiveJanitor[T any](min, max time.Duration, targetWork int) Option[T] {
+	return func(c *config[T]) {
+		c.adaptiveJanitor = true
+		c.adaptiveMinInterval = min
+		c.adaptiveMaxInterval = max
+		c.adaptiveTargetWork = targetWork
+	}
+}
+
+// EventStartOption represents a configuration option for a single
+// EventStart call.
+type EventStartOption[T any] func(*eventStartConfig[T])
+
+// eventStartConfig represents internal per-EventStart-call configuration.
+type eventStartConfig[T any] struct {
+	behavior *Behavior
+}
+
+// WithEventBehavior overrides, for the session produced or reused by
+// this EventStart call, the Tracker's default Behavior.
+func WithEventBehavior[T any](behavior Behavior) EventStartOption[T] {
+	return func(c *eventStartConfig[T]) { c.behavior = &behavior }
+}
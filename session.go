@@ -13,17 +13,61 @@ type SessionMetadata struct {
 
 // Session represents a unique session.
 type Session[T any] struct {
-	mu       sync.Mutex
-	data     T
-	events   map[string]struct{}
-	retired  bool
-	metadata SessionMetadata
+	mu         sync.Mutex
+	data       T
+	events     map[string]struct{}
+	retired    bool
+	metadata   SessionMetadata
+	behavior   Behavior
+	invalidate func()
 }
 
+// Data returns this session's payload. It is nil-safe: calling it on a
+// nil *Session[T] (e.g. the value EventStart returns when a dedup key
+// is locked out) returns the zero value of T instead of panicking.
 func (s *Session[T]) Data() T {
+	if s == nil {
+		var zero T
+		return zero
+	}
 	return s.data
 }
 
+// Invalidate force-ends this session, without waiting for inactivity
+// or the janitor. It is a no-op if the session has already ended.
+func (s *Session[T]) Invalidate() {
+	s.mu.Lock()
+	invalidate := s.invalidate
+	s.mu.Unlock()
+
+	if invalidate != nil {
+		invalidate()
+	}
+}
+
+// SessionSnapshot is a read-only, point-in-time view of a session's
+// public state.
+type SessionSnapshot struct {
+	Metadata SessionMetadata
+	EventIDs []string
+}
+
+// Snapshot returns a point-in-time copy of this session's metadata
+// and the IDs of its in-flight events.
+func (s *Session[T]) Snapshot() SessionSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eventIDs := make([]string, 0, len(s.events))
+	for eventID := range s.events {
+		eventIDs = append(eventIDs, eventID)
+	}
+	return SessionSnapshot{
+		Metadata: s.metadata,
+		EventIDs: eventIDs,
+	}
+}
+
 // shouldRemove returns true if a session should be removed by the janitor.
 func (s *Session[T]) janitorShouldRemove(
 	now time.Time,
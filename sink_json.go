@@ -0,0 +1,78 @@
+package slide
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sinkEvent is the NDJSON record written by JSONSink and
+// RotatingFileSink for every event.
+type sinkEvent struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	Type     string    `json:"type"`
+	DedupKey string    `json:"dedup_key"`
+	EventID  string    `json:"event_id,omitempty"`
+}
+
+// JSONSink is an EventSink[T] that writes one NDJSON line per event to
+// w, tagging each line with a monotonically increasing sequence
+// number. The Tracker dispatches notifications through a single
+// ordered delivery goroutine (see tracker.sinkLoop), so lines are
+// always written in the order the events occurred; the sequence
+// number lets a reader detect a dropped write, not reordering.
+type JSONSink[T any] struct {
+	mu  sync.Mutex
+	w   io.Writer
+	seq atomic.Uint64
+}
+
+// NewJSONSink returns a JSONSink writing to w.
+func NewJSONSink[T any](w io.Writer) *JSONSink[T] {
+	return &JSONSink[T]{w: w}
+}
+
+func (s *JSONSink[T]) write(e sinkEvent) {
+	e.Seq = s.seq.Add(1)
+	e.Time = time.Now()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	b = append(b, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write(b)
+}
+
+// OnEventStart implements EventSink.
+func (s *JSONSink[T]) OnEventStart(dedupKey, eventID string) {
+	s.write(sinkEvent{Type: "event_start", DedupKey: dedupKey, EventID: eventID})
+}
+
+// OnEventEnd implements EventSink.
+func (s *JSONSink[T]) OnEventEnd(dedupKey, eventID string) {
+	s.write(sinkEvent{Type: "event_end", DedupKey: dedupKey, EventID: eventID})
+}
+
+// OnSessionCreated implements EventSink.
+func (s *JSONSink[T]) OnSessionCreated(dedupKey string, metadata *SessionMetadata) {
+	s.write(sinkEvent{Type: "session_created", DedupKey: dedupKey})
+}
+
+// OnSessionRetired implements EventSink.
+func (s *JSONSink[T]) OnSessionRetired(dedupKey string, metadata *SessionMetadata) {
+	s.write(sinkEvent{Type: "session_retired", DedupKey: dedupKey})
+}
+
+// OnSessionEnded implements EventSink.
+func (s *JSONSink[T]) OnSessionEnded(dedupKey string, data T, metadata *SessionMetadata) {
+	s.write(sinkEvent{Type: "session_ended", DedupKey: dedupKey})
+}
+
+var _ EventSink[any] = (*JSONSink[any])(nil)
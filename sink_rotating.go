@@ -0,0 +1,132 @@
+package slide
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RotatingFileSink is an EventSink[T] that writes NDJSON lines to a
+// file under dir, closing it and opening a new one once maxSize bytes
+// have been written to it or maxAge has elapsed since it was opened.
+// A zero maxSize or maxAge disables that rotation trigger. As with
+// JSONSink, the Tracker's single ordered delivery goroutine guarantees
+// lines are written in the order events occurred, so the sequence
+// number only needs to detect a dropped write, not reordering.
+type RotatingFileSink[T any] struct {
+	mu      sync.Mutex
+	dir     string
+	prefix  string
+	maxSize int64
+	maxAge  time.Duration
+	seq     atomic.Uint64
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingFileSink returns a RotatingFileSink writing NDJSON files
+// named "<prefix>-<timestamp>.ndjson" under dir, creating dir if it
+// does not already exist.
+func NewRotatingFileSink[T any](dir, prefix string, maxSize int64, maxAge time.Duration) (*RotatingFileSink[T], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create event sink directory: %w", err)
+	}
+	s := &RotatingFileSink[T]{dir: dir, prefix: prefix, maxSize: maxSize, maxAge: maxAge}
+	if err := s.rotateLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the currently open file.
+func (s *RotatingFileSink[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+func (s *RotatingFileSink[T]) shouldRotateLocked() bool {
+	if s.maxSize > 0 && s.size >= s.maxSize {
+		return true
+	}
+	if s.maxAge > 0 && time.Since(s.openedAt) >= s.maxAge {
+		return true
+	}
+	return false
+}
+
+func (s *RotatingFileSink[T]) rotateLocked() error {
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+
+	name := fmt.Sprintf("%s-%d.ndjson", s.prefix, time.Now().UnixNano())
+	f, err := os.OpenFile(filepath.Join(s.dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open event sink file: %w", err)
+	}
+	s.file = f
+	s.size = 0
+	s.openedAt = time.Now()
+	return nil
+}
+
+func (s *RotatingFileSink[T]) write(e sinkEvent) {
+	e.Seq = s.seq.Add(1)
+	e.Time = time.Now()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	b = append(b, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shouldRotateLocked() {
+		if err := s.rotateLocked(); err != nil {
+			return
+		}
+	}
+	n, err := s.file.Write(b)
+	if err == nil {
+		s.size += int64(n)
+	}
+}
+
+// OnEventStart implements EventSink.
+func (s *RotatingFileSink[T]) OnEventStart(dedupKey, eventID string) {
+	s.write(sinkEvent{Type: "event_start", DedupKey: dedupKey, EventID: eventID})
+}
+
+// OnEventEnd implements EventSink.
+func (s *RotatingFileSink[T]) OnEventEnd(dedupKey, eventID string) {
+	s.write(sinkEvent{Type: "event_end", DedupKey: dedupKey, EventID: eventID})
+}
+
+// OnSessionCreated implements EventSink.
+func (s *RotatingFileSink[T]) OnSessionCreated(dedupKey string, metadata *SessionMetadata) {
+	s.write(sinkEvent{Type: "session_created", DedupKey: dedupKey})
+}
+
+// OnSessionRetired implements EventSink.
+func (s *RotatingFileSink[T]) OnSessionRetired(dedupKey string, metadata *SessionMetadata) {
+	s.write(sinkEvent{Type: "session_retired", DedupKey: dedupKey})
+}
+
+// OnSessionEnded implements EventSink.
+func (s *RotatingFileSink[T]) OnSessionEnded(dedupKey string, data T, metadata *SessionMetadata) {
+	s.write(sinkEvent{Type: "session_ended", DedupKey: dedupKey})
+}
+
+var _ EventSink[any] = (*RotatingFileSink[any])(nil)
@@ -0,0 +1,93 @@
+package slide
+
+// StoredSession is the serializable representation of a Session[T],
+// i.e. everything a Store needs to persist a session and rehydrate it
+// later, potentially in a different process.
+type StoredSession[T any] struct {
+	DedupKey string
+	Data     T
+	Metadata SessionMetadata
+	Events   []string
+	Behavior Behavior
+}
+
+// Codec encodes and decodes a StoredSession[T] to and from bytes so
+// that a Store can persist sessions outside of process memory.
+type Codec[T any] interface {
+	Encode(s *StoredSession[T]) ([]byte, error)
+	Decode(b []byte) (*StoredSession[T], error)
+}
+
+// Store represents a pluggable backend for active session state. The
+// default Tracker[T] is backed by an in-memory store; alternative
+// implementations (e.g. FileStore, RedisStore) let sessions survive a
+// process restart.
+//
+// Implementations are responsible for their own internal
+// synchronization; Tracker[T] additionally holds its own lock around
+// each read-modify-write sequence on a Store (e.g. expiring a session
+// and replacing it), so that the sequence is atomic regardless of the
+// backend. Read-only calls such as Get and Iterate made by Tracker[T]'s
+// query methods are not serialized.
+type Store[T any] interface {
+	// Get returns the session associated with dedupKey, if any. It
+	// must return the same *Session[T] pointer across calls for as
+	// long as that session is active, so that callers (notably the
+	// janitor and Session[T].Invalidate) can use pointer identity to
+	// tell whether the session they are holding is still the one the
+	// store considers current. Implementations that persist outside
+	// process memory (e.g. FileStore, RedisStore) need an in-process
+	// identity cache to satisfy this; see FileStore.cache.
+	Get(dedupKey string) (*Session[T], bool)
+	// Put associates dedupKey with session, overwriting any existing
+	// entry.
+	Put(dedupKey string, session *Session[T])
+	// Delete removes the session associated with dedupKey, if any.
+	Delete(dedupKey string)
+	// Iterate calls fn for every active session, stopping early if fn
+	// returns false.
+	Iterate(fn func(dedupKey string, session *Session[T]) bool)
+	// MapEvent associates eventID with dedupKey.
+	MapEvent(eventID, dedupKey string)
+	// LookupEvent returns the dedup key associated with eventID, if any.
+	LookupEvent(eventID string) (string, bool)
+	// UnmapEvent removes the eventID to dedup key association.
+	UnmapEvent(eventID string)
+	// SnapshotForJanitor returns a point-in-time copy of all active
+	// sessions for the janitor to evaluate without holding the Store
+	// locked for the duration of a sweep.
+	SnapshotForJanitor() map[string]*Session[T]
+}
+
+// toStoredSession captures session under dedupKey into its
+// serializable form.
+func toStoredSession[T any](dedupKey string, session *Session[T]) *StoredSession[T] {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	events := make([]string, 0, len(session.events))
+	for eventID := range session.events {
+		events = append(events, eventID)
+	}
+	return &StoredSession[T]{
+		DedupKey: dedupKey,
+		Data:     session.data,
+		Metadata: session.metadata,
+		Events:   events,
+		Behavior: session.behavior,
+	}
+}
+
+// fromStoredSession rehydrates a Session[T] from its serializable form.
+func fromStoredSession[T any](stored *StoredSession[T]) *Session[T] {
+	events := make(map[string]struct{}, len(stored.Events))
+	for _, eventID := range stored.Events {
+		events[eventID] = struct{}{}
+	}
+	return &Session[T]{
+		data:     stored.Data,
+		events:   events,
+		metadata: stored.Metadata,
+		behavior: stored.Behavior,
+	}
+}
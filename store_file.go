@@ -0,0 +1,191 @@
+package slide
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JSONCodec is a Codec[T] backed by encoding/json. It is adequate for
+// any T that round-trips through json.Marshal/json.Unmarshal.
+type JSONCodec[T any] struct{}
+
+// Encode implements Codec.
+func (JSONCodec[T]) Encode(s *StoredSession[T]) ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Decode implements Codec.
+func (JSONCodec[T]) Decode(b []byte) (*StoredSession[T], error) {
+	var s StoredSession[T]
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// FileStore is a Store[T] that persists each session as a JSON file on
+// disk, sharded into subdirectories by the first two hex characters of
+// a hash of its dedup key (the same layout Beego's file session
+// provider uses), so sessions survive a process restart.
+//
+// The event-to-session mapping is kept in memory only: in-flight
+// events (e.g. an HTTP request being served) don't survive a process
+// restart either way, so there is nothing useful to persist for them.
+//
+// cache holds the live *Session[T] for every dedup key this process
+// has seen, so that Get returns the same pointer on every call instead
+// of a freshly decoded copy (decoding always builds a new session with
+// its own mutex and in-place mutations would otherwise never reach the
+// caller or the janitor). It is populated lazily by Get/Iterate and
+// kept in sync by Put/Delete.
+type FileStore[T any] struct {
+	mu    sync.RWMutex
+	dir   string
+	codec Codec[T]
+
+	eventToSession map[string]string
+	cache          map[string]*Session[T]
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating dir if it
+// does not already exist.
+func NewFileStore[T any](dir string, codec Codec[T]) (*FileStore[T], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create session store directory: %w", err)
+	}
+	return &FileStore[T]{
+		dir:            dir,
+		codec:          codec,
+		eventToSession: make(map[string]string),
+		cache:          make(map[string]*Session[T]),
+	}, nil
+}
+
+// path returns the on-disk path for dedupKey, sharded by the first two
+// hex characters of sha256(dedupKey) so no single directory ends up
+// with one file per session.
+func (f *FileStore[T]) path(dedupKey string) string {
+	sum := sha256.Sum256([]byte(dedupKey))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(f.dir, name[:2], name+".json")
+}
+
+func (f *FileStore[T]) Get(dedupKey string) (*Session[T], bool) {
+	f.mu.RLock()
+	if session, ok := f.cache[dedupKey]; ok {
+		f.mu.RUnlock()
+		return session, true
+	}
+	f.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// another goroutine may have populated the cache while we were
+	// waiting for the write lock.
+	if session, ok := f.cache[dedupKey]; ok {
+		return session, true
+	}
+
+	b, err := os.ReadFile(f.path(dedupKey))
+	if err != nil {
+		return nil, false
+	}
+	stored, err := f.codec.Decode(b)
+	if err != nil {
+		return nil, false
+	}
+	session := fromStoredSession(stored)
+	f.cache[dedupKey] = session
+	return session, true
+}
+
+func (f *FileStore[T]) Put(dedupKey string, session *Session[T]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, err := f.codec.Encode(toStoredSession(dedupKey, session))
+	if err != nil {
+		return
+	}
+
+	p := f.path(dedupKey)
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return
+	}
+	if err := os.WriteFile(p, b, 0o600); err != nil {
+		return
+	}
+	f.cache[dedupKey] = session
+}
+
+func (f *FileStore[T]) Delete(dedupKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_ = os.Remove(f.path(dedupKey))
+	delete(f.cache, dedupKey)
+}
+
+func (f *FileStore[T]) Iterate(fn func(dedupKey string, session *Session[T]) bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_ = filepath.WalkDir(f.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(p) != ".json" {
+			return nil
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil
+		}
+		stored, err := f.codec.Decode(b)
+		if err != nil {
+			return nil
+		}
+		session, ok := f.cache[stored.DedupKey]
+		if !ok {
+			session = fromStoredSession(stored)
+			f.cache[stored.DedupKey] = session
+		}
+		if !fn(stored.DedupKey, session) {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+}
+
+func (f *FileStore[T]) MapEvent(eventID, dedupKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.eventToSession[eventID] = dedupKey
+}
+
+func (f *FileStore[T]) LookupEvent(eventID string) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	dedupKey, ok := f.eventToSession[eventID]
+	return dedupKey, ok
+}
+
+func (f *FileStore[T]) UnmapEvent(eventID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.eventToSession, eventID)
+}
+
+func (f *FileStore[T]) SnapshotForJanitor() map[string]*Session[T] {
+	snapshot := make(map[string]*Session[T])
+	f.Iterate(func(dedupKey string, session *Session[T]) bool {
+		snapshot[dedupKey] = session
+		return true
+	})
+	return snapshot
+}
+
+var _ Store[any] = (*FileStore[any])(nil)
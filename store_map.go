@@ -0,0 +1,83 @@
+package slide
+
+import (
+	"maps"
+	"sync"
+)
+
+// mapStore is the default in-memory Store[T]. It backs every
+// Tracker[T] unless a different Store is supplied via WithStore.
+type mapStore[T any] struct {
+	mu             sync.RWMutex
+	sessions       map[string]*Session[T]
+	eventToSession map[string]string
+}
+
+// newMapStore returns a new empty mapStore.
+func newMapStore[T any]() *mapStore[T] {
+	return &mapStore[T]{
+		sessions:       make(map[string]*Session[T]),
+		eventToSession: make(map[string]string),
+	}
+}
+
+func (s *mapStore[T]) Get(dedupKey string) (*Session[T], bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, ok := s.sessions[dedupKey]
+	return session, ok
+}
+
+func (s *mapStore[T]) Put(dedupKey string, session *Session[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[dedupKey] = session
+}
+
+func (s *mapStore[T]) Delete(dedupKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, dedupKey)
+}
+
+func (s *mapStore[T]) Iterate(fn func(dedupKey string, session *Session[T]) bool) {
+	s.mu.RLock()
+	snapshot := make(map[string]*Session[T], len(s.sessions))
+	maps.Copy(snapshot, s.sessions)
+	s.mu.RUnlock()
+
+	for dedupKey, session := range snapshot {
+		if !fn(dedupKey, session) {
+			return
+		}
+	}
+}
+
+func (s *mapStore[T]) MapEvent(eventID, dedupKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eventToSession[eventID] = dedupKey
+}
+
+func (s *mapStore[T]) LookupEvent(eventID string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dedupKey, ok := s.eventToSession[eventID]
+	return dedupKey, ok
+}
+
+func (s *mapStore[T]) UnmapEvent(eventID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.eventToSession, eventID)
+}
+
+func (s *mapStore[T]) SnapshotForJanitor() map[string]*Session[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot := make(map[string]*Session[T], len(s.sessions))
+	maps.Copy(snapshot, s.sessions)
+	return snapshot
+}
+
+var _ Store[any] = (*mapStore[any])(nil)
@@ -0,0 +1,164 @@
+package slide
+
+import (
+	"context"
+	"sync"
+)
+
+// RedisClient is the minimal surface RedisStore needs from a Redis
+// client, satisfied by e.g. github.com/redis/go-redis/v9's *redis.Client
+// wrapped to return ([]byte, nil) for a missing key instead of an
+// error. Kept minimal so this package does not depend on a specific
+// Redis driver.
+type RedisClient interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Del(ctx context.Context, key string) error
+	Keys(ctx context.Context, pattern string) ([]string, error)
+}
+
+// RedisStore is a sketch of a Redis-backed Store[T]: sessions are
+// stored under key "<prefix>session:<dedupKey>" and the event-to-session
+// mapping under "<prefix>event:<eventID>". Iterate uses a KEYS scan,
+// which is fine for debugging/low-volume trackers but should be
+// replaced with SCAN for large deployments.
+//
+// This is a starting point for production use, not a finished driver:
+// callers needing retries, pipelining, or TTL-based expiry should wrap
+// RedisClient accordingly.
+//
+// cache holds the live *Session[T] for every dedup key this process
+// has seen, so Get returns the same pointer on every call instead of a
+// freshly decoded copy; see FileStore's cache field for why that
+// matters.
+type RedisStore[T any] struct {
+	mu     sync.RWMutex
+	client RedisClient
+	codec  Codec[T]
+	prefix string
+	cache  map[string]*Session[T]
+}
+
+// NewRedisStore returns a RedisStore keyed under prefix.
+func NewRedisStore[T any](client RedisClient, codec Codec[T], prefix string) *RedisStore[T] {
+	return &RedisStore[T]{
+		client: client,
+		codec:  codec,
+		prefix: prefix,
+		cache:  make(map[string]*Session[T]),
+	}
+}
+
+func (r *RedisStore[T]) sessionKey(dedupKey string) string {
+	return r.prefix + "session:" + dedupKey
+}
+
+func (r *RedisStore[T]) eventKey(eventID string) string {
+	return r.prefix + "event:" + eventID
+}
+
+func (r *RedisStore[T]) Get(dedupKey string) (*Session[T], bool) {
+	r.mu.RLock()
+	if session, ok := r.cache[dedupKey]; ok {
+		r.mu.RUnlock()
+		return session, true
+	}
+	r.mu.RUnlock()
+
+	b, err := r.client.Get(context.Background(), r.sessionKey(dedupKey))
+	if err != nil || b == nil {
+		return nil, false
+	}
+	stored, err := r.codec.Decode(b)
+	if err != nil {
+		return nil, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if session, ok := r.cache[dedupKey]; ok {
+		return session, true
+	}
+	session := fromStoredSession(stored)
+	r.cache[dedupKey] = session
+	return session, true
+}
+
+func (r *RedisStore[T]) Put(dedupKey string, session *Session[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := r.codec.Encode(toStoredSession(dedupKey, session))
+	if err != nil {
+		return
+	}
+	if err := r.client.Set(context.Background(), r.sessionKey(dedupKey), b); err != nil {
+		return
+	}
+	r.cache[dedupKey] = session
+}
+
+func (r *RedisStore[T]) Delete(dedupKey string) {
+	_ = r.client.Del(context.Background(), r.sessionKey(dedupKey))
+
+	r.mu.Lock()
+	delete(r.cache, dedupKey)
+	r.mu.Unlock()
+}
+
+func (r *RedisStore[T]) Iterate(fn func(dedupKey string, session *Session[T]) bool) {
+	ctx := context.Background()
+	keys, err := r.client.Keys(ctx, r.prefix+"session:*")
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		b, err := r.client.Get(ctx, key)
+		if err != nil || b == nil {
+			continue
+		}
+		stored, err := r.codec.Decode(b)
+		if err != nil {
+			continue
+		}
+
+		r.mu.Lock()
+		session, ok := r.cache[stored.DedupKey]
+		if !ok {
+			session = fromStoredSession(stored)
+			r.cache[stored.DedupKey] = session
+		}
+		r.mu.Unlock()
+
+		if !fn(stored.DedupKey, session) {
+			return
+		}
+	}
+}
+
+func (r *RedisStore[T]) MapEvent(eventID, dedupKey string) {
+	_ = r.client.Set(context.Background(), r.eventKey(eventID), []byte(dedupKey))
+}
+
+func (r *RedisStore[T]) LookupEvent(eventID string) (string, bool) {
+	b, err := r.client.Get(context.Background(), r.eventKey(eventID))
+	if err != nil || b == nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+func (r *RedisStore[T]) UnmapEvent(eventID string) {
+	_ = r.client.Del(context.Background(), r.eventKey(eventID))
+}
+
+func (r *RedisStore[T]) SnapshotForJanitor() map[string]*Session[T] {
+	snapshot := make(map[string]*Session[T])
+	r.Iterate(func(dedupKey string, session *Session[T]) bool {
+		snapshot[dedupKey] = session
+		return true
+	})
+	return snapshot
+}
+
+var _ Store[any] = (*RedisStore[any])(nil)
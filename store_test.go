@@ -0,0 +1,212 @@
+package slide
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedisClient is a minimal in-memory RedisClient for exercising
+// RedisStore without a real Redis server.
+type fakeRedisClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newFakeRedisClient() *fakeRedisClient {
+	return &fakeRedisClient{data: make(map[string][]byte)}
+}
+
+func (f *fakeRedisClient) Get(_ context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.data[key], nil
+}
+
+func (f *fakeRedisClient) Set(_ context.Context, key string, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = value
+	return nil
+}
+
+func (f *fakeRedisClient) Del(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, key)
+	return nil
+}
+
+func (f *fakeRedisClient) Keys(_ context.Context, pattern string) ([]string, error) {
+	prefix := strings.TrimSuffix(pattern, "*")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for k := range f.data {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// storeConstructors returns, for every Store[T] implementation, a
+// function that builds a fresh instance for a test to use.
+func storeConstructors() map[string]func(t *testing.T) Store[*int] {
+	return map[string]func(t *testing.T) Store[*int]{
+		"mapStore": func(t *testing.T) Store[*int] {
+			return newMapStore[*int]()
+		},
+		"FileStore": func(t *testing.T) Store[*int] {
+			store, err := NewFileStore[*int](t.TempDir(), JSONCodec[*int]{})
+			if err != nil {
+				t.Fatalf("NewFileStore: %v", err)
+			}
+			return store
+		},
+		"RedisStore": func(t *testing.T) Store[*int] {
+			return NewRedisStore[*int](newFakeRedisClient(), JSONCodec[*int]{}, "test:")
+		},
+	}
+}
+
+// TestStoreRoundTrip exercises every Store[T] implementation through
+// the same sequence a real Tracker drives it with: issue a session,
+// reuse it for a second event, and let the janitor reclaim it once
+// idle. A Store that hands back a fresh *Session[T] on every Get
+// (losing pointer identity) or that never persists in-place mutations
+// fails either the in-flight-event check or the idle-removal check
+// below.
+func TestStoreRoundTrip(t *testing.T) {
+	for name, newStore := range storeConstructors() {
+		t.Run(name, func(t *testing.T) {
+			tracker := NewTracker[*int](
+				func(string) *int { n := 0; return &n },
+				WithStore[*int](newStore(t)),
+				WithJanitorInterval[*int](10*time.Millisecond),
+				WithInactivityTimeout[*int](20*time.Millisecond),
+			)
+			defer tracker.Stop()
+
+			if s := tracker.EventStart("dedup", "ev1"); s == nil {
+				t.Fatal("expected a non-nil session")
+			}
+			if err := tracker.EventEnd("ev1"); err != nil {
+				t.Fatalf("EventEnd(ev1): %v", err)
+			}
+
+			if s := tracker.EventStart("dedup", "ev2"); s == nil {
+				t.Fatal("expected a non-nil session")
+			}
+			session, ok := tracker.Get("dedup")
+			if !ok {
+				t.Fatal("expected session to still be tracked")
+			}
+			if snap := session.Snapshot(); len(snap.EventIDs) != 1 || snap.EventIDs[0] != "ev2" {
+				t.Fatalf("expected exactly [ev2] to be in flight, got %v", snap.EventIDs)
+			}
+			if err := tracker.EventEnd("ev2"); err != nil {
+				t.Fatalf("EventEnd(ev2): %v", err)
+			}
+
+			deadline := time.Now().Add(time.Second)
+			for tracker.Len() != 0 {
+				if time.Now().After(deadline) {
+					t.Fatal("expected the janitor to remove the now-idle session")
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+		})
+	}
+}
+
+// TestInvalidate exercises both ways of force-ending a session -
+// Session[T].Invalidate and Tracker[T].Invalidate(dedupKey) - against
+// every Store[T] implementation.
+func TestInvalidate(t *testing.T) {
+	invalidators := map[string]func(tracker Tracker[*int], session *Session[*int], dedupKey string) error{
+		"Session.Invalidate": func(_ Tracker[*int], session *Session[*int], _ string) error {
+			session.Invalidate()
+			return nil
+		},
+		"Tracker.Invalidate": func(tracker Tracker[*int], _ *Session[*int], dedupKey string) error {
+			return tracker.Invalidate(dedupKey)
+		},
+	}
+
+	for storeName, newStore := range storeConstructors() {
+		for invName, invalidate := range invalidators {
+			t.Run(storeName+"/"+invName, func(t *testing.T) {
+				tracker := NewTracker[*int](
+					func(string) *int { n := 0; return &n },
+					WithStore[*int](newStore(t)),
+				)
+				defer tracker.Stop()
+
+				session := tracker.EventStart("dedup", "ev1")
+				if session == nil {
+					t.Fatal("expected a non-nil session")
+				}
+				if err := invalidate(tracker, session, "dedup"); err != nil {
+					t.Fatalf("invalidate: %v", err)
+				}
+				if _, ok := tracker.Get("dedup"); ok {
+					t.Fatal("expected the session to be gone after invalidation")
+				}
+			})
+		}
+	}
+}
+
+// TestSessionInvalidateAfterRestart reproduces a process restart by
+// handing the same Store instance to a second, independent Tracker: a
+// session rehydrated this way (never built by newSession) must still
+// have a working Invalidate, not just a session created in-process.
+// mapStore keeps nothing outside process memory, so for it the session
+// tracker2 gets back is the one tracker1 created.
+func TestSessionInvalidateAfterRestart(t *testing.T) {
+	for name, newStore := range storeConstructors() {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+
+			tracker1 := NewTracker[*int](
+				func(string) *int { n := 0; return &n },
+				WithStore[*int](store),
+			)
+			if s := tracker1.EventStart("dedup", "ev1"); s == nil {
+				t.Fatal("expected a non-nil session")
+			}
+			tracker1.Stop()
+
+			// drop the in-process identity cache of the persisting
+			// stores so that tracker2 has to decode the session from
+			// the backing files/keys, as a fresh process would.
+			switch s := store.(type) {
+			case *FileStore[*int]:
+				s.cache = make(map[string]*Session[*int])
+			case *RedisStore[*int]:
+				s.cache = make(map[string]*Session[*int])
+			}
+
+			// a new Tracker pointed at the same Store, simulating a
+			// fresh process that only shares the persisted state.
+			tracker2 := NewTracker[*int](
+				func(string) *int { n := 0; return &n },
+				WithStore[*int](store),
+			)
+			defer tracker2.Stop()
+
+			session, ok := tracker2.Get("dedup")
+			if !ok {
+				t.Fatal("expected the rehydrated session to still be tracked")
+			}
+			session.Invalidate()
+
+			if _, ok := tracker2.Get("dedup"); ok {
+				t.Fatal("expected Invalidate to remove the rehydrated session")
+			}
+		})
+	}
+}
@@ -2,31 +2,55 @@ package slide
 
 import (
 	"fmt"
-	"maps"
+	"math/rand"
 	"sync"
 	"time"
 )
 
 // tracker is the default implementation of Tracker.
 type tracker[T any] struct {
-	mu              sync.RWMutex
-	sessions        map[string]*Session[T]
-	eventToSession  map[string]*Session[T]
-	retiredSessions map[*Session[T]]struct{}
-	ticker          *time.Ticker
+	mu                sync.Mutex
+	store             Store[T]
+	retiredSessions   map[*Session[T]]string
+	lockedUntil       map[string]time.Time
+	stop              chan struct{}
+	stopOnce          sync.Once
+	sinkNotifications chan sinkNotification[T]
 
-	sessionInitFunc   SessionInitFunc[T]
-	inactivityTimeout time.Duration
-	maxSessionTimeout *time.Duration
-	onSessionEnd      OnEndFunc[T]
+	sessionInitFunc     SessionInitFunc[T]
+	inactivityTimeout   time.Duration
+	maxSessionTimeout   *time.Duration
+	onSessionEnd        OnEndFunc[T]
+	onDelete            OnDeleteFunc[T]
+	sessionBehavior     Behavior
+	livenessCheck       LivenessCheckFunc[T]
+	lockDelay           time.Duration
+	eventSinks          []EventSink[T]
+	janitorInterval     time.Duration
+	janitorJitter       float64
+	adaptiveJanitor     bool
+	adaptiveMinInterval time.Duration
+	adaptiveMaxInterval time.Duration
+	adaptiveTargetWork  int
 }
 
 // config represents internal tracker configuration.
 type config[T any] struct {
-	janitorInterval   time.Duration
-	inactivityTimeout time.Duration
-	maxSessionTimeout *time.Duration
-	onSessionEnd      OnEndFunc[T]
+	janitorInterval     time.Duration
+	inactivityTimeout   time.Duration
+	maxSessionTimeout   *time.Duration
+	onSessionEnd        OnEndFunc[T]
+	onDelete            OnDeleteFunc[T]
+	sessionBehavior     Behavior
+	livenessCheck       LivenessCheckFunc[T]
+	lockDelay           time.Duration
+	eventSinks          []EventSink[T]
+	janitorJitter       float64
+	adaptiveJanitor     bool
+	adaptiveMinInterval time.Duration
+	adaptiveMaxInterval time.Duration
+	adaptiveTargetWork  int
+	store               Store[T]
 }
 
 // NewTracker returns a new sliding window tracker.
@@ -36,20 +60,43 @@ func NewTracker[T any](sessionInitFunc SessionInitFunc[T], opts ...Option[T]) Tr
 		inactivityTimeout: 15 * time.Minute,
 		maxSessionTimeout: nil,
 		onSessionEnd:      nil,
+		onDelete:          nil,
+		sessionBehavior:   BehaviorRelease,
+		livenessCheck:     nil,
+		lockDelay:         0,
+		store:             nil,
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if cfg.store == nil {
+		cfg.store = newMapStore[T]()
+	}
 	t := &tracker[T]{
-		mu:                sync.RWMutex{},
-		sessions:          make(map[string]*Session[T]),
-		eventToSession:    make(map[string]*Session[T]),
-		retiredSessions:   make(map[*Session[T]]struct{}),
-		ticker:            time.NewTicker(cfg.janitorInterval),
-		sessionInitFunc:   sessionInitFunc,
-		inactivityTimeout: cfg.inactivityTimeout,
-		maxSessionTimeout: cfg.maxSessionTimeout,
-		onSessionEnd:      cfg.onSessionEnd,
+		mu:                  sync.Mutex{},
+		store:               cfg.store,
+		retiredSessions:     make(map[*Session[T]]string),
+		lockedUntil:         make(map[string]time.Time),
+		stop:                make(chan struct{}),
+		sessionInitFunc:     sessionInitFunc,
+		inactivityTimeout:   cfg.inactivityTimeout,
+		maxSessionTimeout:   cfg.maxSessionTimeout,
+		onSessionEnd:        cfg.onSessionEnd,
+		onDelete:            cfg.onDelete,
+		sessionBehavior:     cfg.sessionBehavior,
+		livenessCheck:       cfg.livenessCheck,
+		lockDelay:           cfg.lockDelay,
+		eventSinks:          cfg.eventSinks,
+		janitorInterval:     cfg.janitorInterval,
+		janitorJitter:       cfg.janitorJitter,
+		adaptiveJanitor:     cfg.adaptiveJanitor,
+		adaptiveMinInterval: cfg.adaptiveMinInterval,
+		adaptiveMaxInterval: cfg.adaptiveMaxInterval,
+		adaptiveTargetWork:  cfg.adaptiveTargetWork,
+	}
+	if len(t.eventSinks) > 0 {
+		t.sinkNotifications = make(chan sinkNotification[T], 256)
+		go t.sinkLoop()
 	}
 	go t.janitor()
 	return t
@@ -57,21 +104,29 @@ func NewTracker[T any](sessionInitFunc SessionInitFunc[T], opts ...Option[T]) Tr
 
 // Stop stops the tracker.
 func (t *tracker[T]) Stop() {
-	t.ticker.Stop()
+	t.stopOnce.Do(func() { close(t.stop) })
 }
 
 // EventStart marks the start of a session's event.
 func (t *tracker[T]) EventStart(
 	sessionDedupKey string,
 	eventID string,
+	opts ...EventStartOption[T],
 ) *Session[T] {
 	now := time.Now()
 
+	eventCfg := &eventStartConfig[T]{}
+	for _, opt := range opts {
+		opt(eventCfg)
+	}
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	session, exists := t.sessions[sessionDedupKey]
+	session, exists := t.store.Get(sessionDedupKey)
 	if exists {
+		t.wireInvalidate(sessionDedupKey, session)
+
 		if t.maxSessionTimeout != nil {
 			session.mu.Lock()
 			expired := now.Sub(session.metadata.Created) > *t.maxSessionTimeout
@@ -83,21 +138,16 @@ func (t *tracker[T]) EventStart(
 				session.mu.Lock()
 				session.retired = true
 				delete(session.events, eventID)
+				metadata := session.metadata
 				session.mu.Unlock()
 
-				t.retiredSessions[session] = struct{}{}
+				t.retiredSessions[session] = sessionDedupKey
+				t.notifySessionRetired(sessionDedupKey, &metadata)
 
-				newSession := &Session[T]{
-					mu:     sync.Mutex{},
-					data:   t.sessionInitFunc(sessionDedupKey),
-					events: map[string]struct{}{eventID: {}},
-					metadata: SessionMetadata{
-						Created: now,
-						Updated: now,
-					},
-				}
-				t.sessions[sessionDedupKey] = newSession
-				t.eventToSession[eventID] = newSession
+				newSession := t.newSession(sessionDedupKey, eventID, now, eventCfg)
+				t.store.Put(sessionDedupKey, newSession)
+				t.store.MapEvent(eventID, sessionDedupKey)
+				t.notifyEventStart(sessionDedupKey, eventID)
 				return newSession
 			}
 		}
@@ -105,14 +155,65 @@ func (t *tracker[T]) EventStart(
 		// the current session is still valid, so we re-use it
 		session.mu.Lock()
 		session.events[eventID] = struct{}{}
-		t.eventToSession[eventID] = session
 		session.metadata.Updated = now
+		if eventCfg.behavior != nil {
+			session.behavior = *eventCfg.behavior
+		}
 		session.mu.Unlock()
+		// persist the mutated event set/timestamp for Store
+		// implementations that don't share pointer identity with an
+		// external representation (e.g. FileStore, RedisStore).
+		t.store.Put(sessionDedupKey, session)
+		t.store.MapEvent(eventID, sessionDedupKey)
+		t.notifyEventStart(sessionDedupKey, eventID)
 		return session
 	}
 
-	// no session found, create a new one.
-	newSession := &Session[T]{
+	// no session found; if the dedup key is still within its lock
+	// delay from a prior invalidation, refuse to issue a new one.
+	if until, locked := t.lockedUntil[sessionDedupKey]; locked {
+		if now.Before(until) {
+			return nil
+		}
+		delete(t.lockedUntil, sessionDedupKey)
+	}
+
+	newSession := t.newSession(sessionDedupKey, eventID, now, eventCfg)
+	t.store.Put(sessionDedupKey, newSession)
+	t.store.MapEvent(eventID, sessionDedupKey)
+	t.notifyEventStart(sessionDedupKey, eventID)
+	return newSession
+}
+
+// wireInvalidate binds session's Invalidate callback to dedupKey if it
+// isn't already set. Sessions built by newSession are wired at
+// creation time, but a session handed back by Store.Get/Iterate may
+// instead have just been decoded from outside process memory (e.g.
+// FileStore, RedisStore rehydrating it after a restart), in which case
+// it never went through newSession and Session[T].Invalidate would
+// otherwise be a silent no-op.
+func (t *tracker[T]) wireInvalidate(dedupKey string, session *Session[T]) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.invalidate == nil {
+		session.invalidate = func() { t.invalidateSession(dedupKey, session) }
+	}
+}
+
+// newSession builds a new Session[T] for sessionDedupKey, wiring its
+// Behavior and Invalidate callback. Callers must hold t.mu.
+func (t *tracker[T]) newSession(
+	sessionDedupKey string,
+	eventID string,
+	now time.Time,
+	eventCfg *eventStartConfig[T],
+) *Session[T] {
+	behavior := t.sessionBehavior
+	if eventCfg.behavior != nil {
+		behavior = *eventCfg.behavior
+	}
+
+	session := &Session[T]{
 		mu:     sync.Mutex{},
 		data:   t.sessionInitFunc(sessionDedupKey),
 		events: map[string]struct{}{eventID: {}},
@@ -120,93 +221,433 @@ func (t *tracker[T]) EventStart(
 			Created: now,
 			Updated: now,
 		},
+		behavior: behavior,
 	}
-	t.sessions[sessionDedupKey] = newSession
-	t.eventToSession[eventID] = newSession
-	return newSession
+	session.invalidate = func() { t.invalidateSession(sessionDedupKey, session) }
+	t.notifySessionCreated(sessionDedupKey, &session.metadata)
+	return session
 }
 
 // EventEnd marks the end of session's event.
 func (t *tracker[T]) EventEnd(
 	eventID string,
 ) error {
-	// Try to locate in active sessions
-	t.mu.RLock()
-	session, ok := t.eventToSession[eventID]
-	t.mu.RUnlock()
+	t.mu.Lock()
+	dedupKey, ok := t.store.LookupEvent(eventID)
+	if !ok {
+		t.mu.Unlock()
+		return fmt.Errorf("event %s is not associated with any session", eventID)
+	}
+
+	session, ok := t.store.Get(dedupKey)
+	if ok {
+		session.mu.Lock()
+		_, ok = session.events[eventID]
+		session.mu.Unlock()
+	}
+	active := ok
+	if !ok {
+		// the event is not in the active session under dedupKey (if
+		// any), so that session has already been replaced; the event
+		// must belong to a retired one, which we find by scanning
+		// since it is no longer addressable by dedup key.
+		for retired := range t.retiredSessions {
+			retired.mu.Lock()
+			_, has := retired.events[eventID]
+			retired.mu.Unlock()
+			if has {
+				session = retired
+				ok = true
+				break
+			}
+		}
+	}
+	t.store.UnmapEvent(eventID)
+	t.mu.Unlock()
+
 	if !ok {
 		return fmt.Errorf("event %s is not associated with any session", eventID)
 	}
 
+	t.notifyEventEnd(dedupKey, eventID)
+
 	session.mu.Lock()
 	delete(session.events, eventID)
 	session.metadata.Updated = time.Now()
 	shouldDelete := len(session.events) == 0 && session.retired
 	session.mu.Unlock()
 
-	// Clean up the mapping
-	t.mu.Lock()
-	delete(t.eventToSession, eventID)
-	t.mu.Unlock()
+	if active {
+		// persist the mutated event set/timestamp for Store
+		// implementations that don't share pointer identity with an
+		// external representation. Retired sessions are no longer
+		// addressable by dedup key in the store, so there is nothing
+		// to persist them under. The session is persisted only if it
+		// is still the current one, so that a session invalidated or
+		// swept since t.mu was released is not put back.
+		t.mu.Lock()
+		if current, ok := t.store.Get(dedupKey); ok && current == session {
+			t.store.Put(dedupKey, session)
+		}
+		t.mu.Unlock()
+	}
 
 	if shouldDelete {
 		t.mu.Lock()
 		delete(t.retiredSessions, session)
 		t.mu.Unlock()
-		if t.onSessionEnd != nil {
-			go t.onSessionEnd(session.data, &session.metadata)
-		}
+		t.endSession(dedupKey, session)
 	}
 
 	return nil
 }
 
-// janitor is the clean-up loop of the tracker.
-func (t *tracker[T]) janitor() {
-	for range t.ticker.C {
-		now := time.Now()
-
-		// clean up active sessions
-
-		t.mu.RLock()
-		snapshot := make(map[string]*Session[T], len(t.sessions))
-		maps.Copy(snapshot, t.sessions)
-		t.mu.RUnlock()
-
-		for dedupKey, session := range snapshot {
-			if session.janitorShouldRemove(now, t.inactivityTimeout, t.maxSessionTimeout) {
-				t.mu.Lock()
-				current, ok := t.sessions[dedupKey]
-				if ok && current == session {
-					delete(t.sessions, dedupKey)
-				}
-				t.mu.Unlock()
+// Get returns the active session for sessionDedupKey, if any.
+func (t *tracker[T]) Get(sessionDedupKey string) (*Session[T], bool) {
+	session, ok := t.store.Get(sessionDedupKey)
+	if ok {
+		t.wireInvalidate(sessionDedupKey, session)
+	}
+	return session, ok
+}
+
+// Range calls fn for every active session, stopping early if fn
+// returns false.
+func (t *tracker[T]) Range(fn func(sessionDedupKey string, session *Session[T]) bool) {
+	t.store.Iterate(func(dedupKey string, session *Session[T]) bool {
+		t.wireInvalidate(dedupKey, session)
+		return fn(dedupKey, session)
+	})
+}
+
+// Len returns the number of active sessions.
+func (t *tracker[T]) Len() int {
+	n := 0
+	t.store.Iterate(func(string, *Session[T]) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Stats returns a summary of the Tracker's current state.
+func (t *tracker[T]) Stats() TrackerStats {
+	now := time.Now()
+
+	var active, eventsInFlight int
+	var totalAge time.Duration
+
+	t.store.Iterate(func(dedupKey string, session *Session[T]) bool {
+		active++
+		session.mu.Lock()
+		totalAge += now.Sub(session.metadata.Created)
+		eventsInFlight += len(session.events)
+		session.mu.Unlock()
+		return true
+	})
+
+	t.mu.Lock()
+	retired := len(t.retiredSessions)
+	for session := range t.retiredSessions {
+		session.mu.Lock()
+		eventsInFlight += len(session.events)
+		session.mu.Unlock()
+	}
+	t.mu.Unlock()
+
+	stats := TrackerStats{
+		ActiveSessions:  active,
+		RetiredSessions: retired,
+		EventsInFlight:  eventsInFlight,
+	}
+	if active > 0 {
+		stats.AverageSessionAge = totalAge / time.Duration(active)
+	}
+	return stats
+}
+
+// Invalidate force-ends the active session for sessionDedupKey.
+func (t *tracker[T]) Invalidate(sessionDedupKey string) error {
+	t.mu.Lock()
+	session, ok := t.store.Get(sessionDedupKey)
+	if !ok {
+		t.mu.Unlock()
+		return fmt.Errorf("no active session found for dedup key %s", sessionDedupKey)
+	}
+	t.store.Delete(sessionDedupKey)
+	t.mu.Unlock()
 
-				if ok && current == session && t.onSessionEnd != nil {
-					go t.onSessionEnd(session.data, &session.metadata)
+	t.endSession(sessionDedupKey, session)
+	t.applyLockDelay(sessionDedupKey)
+	return nil
+}
+
+// invalidateSession force-ends session, so long as it is still the
+// one addressed by dedupKey (active) or still awaiting its remaining
+// events (retired). It backs Session[T].Invalidate.
+func (t *tracker[T]) invalidateSession(dedupKey string, session *Session[T]) {
+	t.mu.Lock()
+	removed := false
+	if current, ok := t.store.Get(dedupKey); ok && current == session {
+		t.store.Delete(dedupKey)
+		removed = true
+	} else if _, ok := t.retiredSessions[session]; ok {
+		delete(t.retiredSessions, session)
+		removed = true
+	}
+	t.mu.Unlock()
+
+	if removed {
+		t.endSession(dedupKey, session)
+		t.applyLockDelay(dedupKey)
+	}
+}
+
+// applyLockDelay records that sessionDedupKey should not be issued a
+// new session until the Tracker's lockDelay elapses, matching
+// Consul's LockDelay semantics. It is a no-op if lockDelay is zero.
+func (t *tracker[T]) applyLockDelay(sessionDedupKey string) {
+	if t.lockDelay <= 0 {
+		return
+	}
+	t.mu.Lock()
+	t.lockedUntil[sessionDedupKey] = time.Now().Add(t.lockDelay)
+	t.mu.Unlock()
+}
+
+// endSession runs the tracker's onSessionEnd handler, its onDelete
+// handler if session's Behavior is BehaviorDelete, and notifies every
+// registered EventSink.
+func (t *tracker[T]) endSession(dedupKey string, session *Session[T]) {
+	session.mu.Lock()
+	behavior := session.behavior
+	session.mu.Unlock()
+
+	if t.onSessionEnd != nil {
+		go t.onSessionEnd(session.data, &session.metadata)
+	}
+	if behavior == BehaviorDelete && t.onDelete != nil {
+		go t.onDelete(session.data, &session.metadata)
+	}
+	t.notifySessionEnded(dedupKey, session.data, &session.metadata)
+}
+
+// sinkEventKind identifies which EventSink method a sinkNotification
+// should be dispatched to.
+type sinkEventKind int
+
+const (
+	sinkKindEventStart sinkEventKind = iota
+	sinkKindEventEnd
+	sinkKindSessionCreated
+	sinkKindSessionRetired
+	sinkKindSessionEnded
+)
+
+// sinkNotification carries everything needed to replay a single
+// EventSink callback, in the order it was enqueued.
+type sinkNotification[T any] struct {
+	kind     sinkEventKind
+	dedupKey string
+	eventID  string
+	data     T
+	metadata *SessionMetadata
+}
+
+// sinkLoop is the single ordered delivery path for EventSink
+// notifications. Producers enqueue via enqueueSink in logical order,
+// and this loop dispatches each one to every registered sink before
+// moving to the next, so two notifications about the same session
+// (e.g. the OnEventEnd that triggers an OnSessionEnded) are always
+// delivered in that order instead of racing as independent goroutines.
+// It only runs if at least one EventSink was registered.
+func (t *tracker[T]) sinkLoop() {
+	for {
+		select {
+		case <-t.stop:
+			return
+		case n := <-t.sinkNotifications:
+			for _, sink := range t.eventSinks {
+				switch n.kind {
+				case sinkKindEventStart:
+					sink.OnEventStart(n.dedupKey, n.eventID)
+				case sinkKindEventEnd:
+					sink.OnEventEnd(n.dedupKey, n.eventID)
+				case sinkKindSessionCreated:
+					sink.OnSessionCreated(n.dedupKey, n.metadata)
+				case sinkKindSessionRetired:
+					sink.OnSessionRetired(n.dedupKey, n.metadata)
+				case sinkKindSessionEnded:
+					sink.OnSessionEnded(n.dedupKey, n.data, n.metadata)
 				}
 			}
 		}
+	}
+}
 
-		// clean up retired sessions
+// enqueueSink hands n to sinkLoop, blocking if its buffer is full so a
+// slow sink applies backpressure rather than notifications being
+// dropped or reordered. It is a no-op if no sinks are registered.
+func (t *tracker[T]) enqueueSink(n sinkNotification[T]) {
+	if len(t.eventSinks) == 0 {
+		return
+	}
+	select {
+	case t.sinkNotifications <- n:
+	case <-t.stop:
+	}
+}
+
+// notifyEventStart notifies every registered EventSink that an event
+// started.
+func (t *tracker[T]) notifyEventStart(dedupKey, eventID string) {
+	t.enqueueSink(sinkNotification[T]{kind: sinkKindEventStart, dedupKey: dedupKey, eventID: eventID})
+}
+
+// notifyEventEnd notifies every registered EventSink that an event
+// ended.
+func (t *tracker[T]) notifyEventEnd(dedupKey, eventID string) {
+	t.enqueueSink(sinkNotification[T]{kind: sinkKindEventEnd, dedupKey: dedupKey, eventID: eventID})
+}
+
+// notifySessionCreated notifies every registered EventSink that a new
+// session was created.
+func (t *tracker[T]) notifySessionCreated(dedupKey string, metadata *SessionMetadata) {
+	t.enqueueSink(sinkNotification[T]{kind: sinkKindSessionCreated, dedupKey: dedupKey, metadata: metadata})
+}
 
-		t.mu.RLock()
-		snapshotRetired := make([]*Session[T], 0, len(t.retiredSessions))
-		for sess := range t.retiredSessions {
-			snapshotRetired = append(snapshotRetired, sess)
+// notifySessionRetired notifies every registered EventSink that a
+// session was retired (superseded after exceeding its max session
+// timeout, but still awaiting its remaining in-flight events).
+func (t *tracker[T]) notifySessionRetired(dedupKey string, metadata *SessionMetadata) {
+	t.enqueueSink(sinkNotification[T]{kind: sinkKindSessionRetired, dedupKey: dedupKey, metadata: metadata})
+}
+
+// notifySessionEnded notifies every registered EventSink that a
+// session ended.
+func (t *tracker[T]) notifySessionEnded(dedupKey string, data T, metadata *SessionMetadata) {
+	t.enqueueSink(sinkNotification[T]{kind: sinkKindSessionEnded, dedupKey: dedupKey, data: data, metadata: metadata})
+}
+
+// janitor is the clean-up loop of the tracker. Instead of a fixed
+// ticker, it runs on a resettable timer so the interval can jitter
+// and, in adaptive mode, grow or shrink based on how much work each
+// sweep found.
+func (t *tracker[T]) janitor() {
+	interval := t.janitorInterval
+	if t.adaptiveJanitor {
+		// start inside the adaptive bounds, since a sweep that
+		// removes between 1 and targetWork-1 sessions keeps the
+		// interval as it is.
+		if interval < t.adaptiveMinInterval {
+			interval = t.adaptiveMinInterval
+		}
+		if interval > t.adaptiveMaxInterval {
+			interval = t.adaptiveMaxInterval
+		}
+	}
+	timer := time.NewTimer(t.nextDelay(interval))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-timer.C:
+			removed := t.sweep()
+			interval = t.nextInterval(interval, removed)
+			timer.Reset(t.nextDelay(interval))
+		}
+	}
+}
+
+// nextDelay applies the Tracker's jitter fraction to interval.
+func (t *tracker[T]) nextDelay(interval time.Duration) time.Duration {
+	if t.janitorJitter <= 0 {
+		return interval
+	}
+	lo := float64(interval) * (1 - t.janitorJitter)
+	hi := float64(interval) * (1 + t.janitorJitter)
+	return time.Duration(lo + rand.Float64()*(hi-lo))
+}
+
+// nextInterval grows or shrinks current based on removed, when the
+// Tracker is in adaptive janitor mode.
+func (t *tracker[T]) nextInterval(current time.Duration, removed int) time.Duration {
+	if !t.adaptiveJanitor {
+		return current
+	}
+	switch {
+	case removed >= t.adaptiveTargetWork:
+		current /= 2
+		if current < t.adaptiveMinInterval {
+			current = t.adaptiveMinInterval
+		}
+	case removed == 0:
+		current *= 2
+		if current > t.adaptiveMaxInterval {
+			current = t.adaptiveMaxInterval
+		}
+	}
+	return current
+}
+
+// sweep runs one janitor pass over active and retired sessions,
+// ending any that should be removed, and returns how many were.
+func (t *tracker[T]) sweep() int {
+	now := time.Now()
+	removed := 0
+
+	// clean up active sessions
+
+	snapshot := t.store.SnapshotForJanitor()
+
+	for dedupKey, session := range snapshot {
+		remove := session.janitorShouldRemove(now, t.inactivityTimeout, t.maxSessionTimeout)
+
+		invalidated := false
+		if !remove && t.livenessCheck != nil && !t.livenessCheck(session.data, &session.metadata) {
+			remove = true
+			invalidated = true
 		}
-		t.mu.RUnlock()
 
-		for _, session := range snapshotRetired {
-			if session.janitorShouldRemove(now, t.inactivityTimeout, t.maxSessionTimeout) {
-				t.mu.Lock()
-				delete(t.retiredSessions, session)
-				t.mu.Unlock()
+		if remove {
+			t.mu.Lock()
+			current, ok := t.store.Get(dedupKey)
+			if ok && current == session {
+				t.store.Delete(dedupKey)
+			}
+			t.mu.Unlock()
 
-				if t.onSessionEnd != nil {
-					go t.onSessionEnd(session.data, &session.metadata)
+			if ok && current == session {
+				t.endSession(dedupKey, session)
+				if invalidated {
+					t.applyLockDelay(dedupKey)
 				}
+				removed++
 			}
 		}
 	}
+
+	// clean up retired sessions
+
+	t.mu.Lock()
+	snapshotRetired := make(map[*Session[T]]string, len(t.retiredSessions))
+	for sess, dedupKey := range t.retiredSessions {
+		snapshotRetired[sess] = dedupKey
+	}
+	t.mu.Unlock()
+
+	for session, dedupKey := range snapshotRetired {
+		if session.janitorShouldRemove(now, t.inactivityTimeout, t.maxSessionTimeout) {
+			t.mu.Lock()
+			delete(t.retiredSessions, session)
+			t.mu.Unlock()
+
+			t.endSession(dedupKey, session)
+			removed++
+		}
+	}
+
+	return removed
 }
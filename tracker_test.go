@@ -0,0 +1,56 @@
+package slide
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAdaptiveJanitorInterval exercises nextInterval's halve/double
+// transitions in isolation, without waiting on a real janitor loop.
+func TestAdaptiveJanitorInterval(t *testing.T) {
+	tr := &tracker[int]{
+		adaptiveJanitor:     true,
+		adaptiveMinInterval: 100 * time.Millisecond,
+		adaptiveMaxInterval: 800 * time.Millisecond,
+		adaptiveTargetWork:  5,
+	}
+
+	cases := []struct {
+		name    string
+		current time.Duration
+		removed int
+		want    time.Duration
+	}{
+		{"halves when a sweep hits the target", 400 * time.Millisecond, 5, 200 * time.Millisecond},
+		{"halving floors at the minimum", 150 * time.Millisecond, 5, 100 * time.Millisecond},
+		{"doubles when a sweep removes nothing", 200 * time.Millisecond, 0, 400 * time.Millisecond},
+		{"doubling caps at the maximum", 600 * time.Millisecond, 0, 800 * time.Millisecond},
+		{"holds steady between the thresholds", 300 * time.Millisecond, 2, 300 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := tr.nextInterval(c.current, c.removed)
+			if got != c.want {
+				t.Fatalf("nextInterval(%s, %d) = %s, want %s", c.current, c.removed, got, c.want)
+			}
+		})
+	}
+}
+
+// TestAdaptiveJanitorDisabled confirms nextInterval is a no-op unless
+// adaptiveJanitor is set, regardless of how much work a sweep found.
+func TestAdaptiveJanitorDisabled(t *testing.T) {
+	tr := &tracker[int]{
+		adaptiveMinInterval: 100 * time.Millisecond,
+		adaptiveMaxInterval: 800 * time.Millisecond,
+		adaptiveTargetWork:  5,
+	}
+
+	if got := tr.nextInterval(300*time.Millisecond, 5); got != 300*time.Millisecond {
+		t.Fatalf("nextInterval with adaptiveJanitor disabled = %s, want unchanged 300ms", got)
+	}
+	if got := tr.nextInterval(300*time.Millisecond, 0); got != 300*time.Millisecond {
+		t.Fatalf("nextInterval with adaptiveJanitor disabled = %s, want unchanged 300ms", got)
+	}
+}